@@ -0,0 +1,220 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/paketo-buildpacks/libpak"
+	"github.com/paketo-buildpacks/libpak/bard"
+
+	"github.com/paketo-buildpacks/spring-boot/boot/sbom"
+)
+
+// PlatformAPIEnvVar is the environment variable the lifecycle sets to tell a
+// buildpack which Platform API it is running against.
+const PlatformAPIEnvVar = "CNB_PLATFORM_API"
+
+// Build is the libcnb.Build delegate for the buildpack. It inspects the
+// application's META-INF/MANIFEST.MF and, when Spring-Boot-Version is
+// present, contributes the labels, BOM entries, layers and slices that make
+// up a Spring Boot image.
+type Build struct {
+	Logger bard.Logger
+
+	// SupportedPlatformAPIs are the Platform API versions, in any order,
+	// that this buildpack is able to speak; the highest one the platform
+	// supports is chosen. When empty, the
+	// single legacy version declared in buildpack.toml's `api` key
+	// (context.Buildpack.API) is used instead.
+	SupportedPlatformAPIs []string
+}
+
+func (b Build) Build(context libcnb.BuildContext) (libcnb.BuildResult, error) {
+	manifest, err := NewManifest(context.Application.Path)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to read manifest\n%w", err)
+	}
+
+	version, ok := manifest["Spring-Boot-Version"]
+	if !ok {
+		return libcnb.BuildResult{}, nil
+	}
+
+	slicesSupported, sbomLayerSupported, err := b.negotiatePlatformAPI(context)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to negotiate platform API\n%w", err)
+	}
+
+	result := libcnb.NewBuildResult()
+
+	result.Labels = append(result.Labels, libcnb.Label{Key: "org.springframework.boot.version", Value: version})
+
+	libPath := manifest.LibPath(context.Application.Path)
+
+	configurationMetadataLabels, configurationMetadata, err := NewConfigurationMetadataLabels(context.Application.Path, libPath)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to contribute configuration metadata labels\n%w", err)
+	}
+	result.Labels = append(result.Labels, configurationMetadataLabels...)
+
+	if len(configurationMetadataLabels) > 0 {
+		result.BOM.Entries = append(result.BOM.Entries, NewConfigurationMetadataBOMEntry(configurationMetadata))
+	}
+
+	if title, ok := manifest["Implementation-Title"]; ok {
+		result.Labels = append(result.Labels, libcnb.Label{Key: "org.opencontainers.image.title", Value: title})
+	}
+	if v, ok := manifest["Implementation-Version"]; ok {
+		result.Labels = append(result.Labels, libcnb.Label{Key: "org.opencontainers.image.version", Value: v})
+	}
+
+	dependenciesEntry, dependencies, err := NewDependenciesBOMEntry(libPath)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to contribute dependencies BOM entry\n%w", err)
+	}
+
+	// components recovers the richer Maven coordinates (groupId/artifactId)
+	// behind each dependency; it's needed both to purl-match shadowed
+	// dependencies below and, when requested, to build the SBOM document.
+	components, err := sbom.NewComponents(libPath)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to inspect dependency components\n%w", err)
+	}
+
+	if shadowed := NewShadowedDependencies(context.Buildpack.Metadata, JDKDistribution(context.Plan)); len(shadowed) > 0 {
+		var relationships []string
+		dependencies, relationships = TrimShadowedDependencies(dependencies, components, shadowed)
+		dependenciesEntry.Metadata["dependencies"] = dependencies
+
+		if len(relationships) > 0 {
+			result.Labels = append(result.Labels, libcnb.Label{
+				Key:   ShadowedDependenciesLabel,
+				Value: strings.Join(relationships, ", "),
+			})
+		}
+	}
+	result.BOM.Entries = append(result.BOM.Entries, dependenciesEntry)
+
+	if expression := AggregateLicenseExpression(dependencies); expression != "" {
+		result.Layers = append(result.Layers, NewLicenseMetadataLayerContributor(dependencies, components))
+		result.Labels = append(result.Labels, libcnb.Label{Key: "org.opencontainers.image.licenses", Value: expression})
+	}
+
+	if sbomLayerSupported && sbom.Enabled(context.Plan) {
+		contributor := sbom.NewLayerContributor(components)
+		result.Layers = append(result.Layers, contributor)
+		result.Labels = append(result.Labels, contributor.Label(filepath.Join(context.Layers.Path, contributor.Name())))
+	}
+
+	if isNativeImage(context.Plan) {
+		nativeImage, err := NewNativeImageLayerContributor(context.Application.Path, "application", b.Logger)
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to contribute native-image layer\n%w", err)
+		}
+		result.Layers = append(result.Layers, nativeImage)
+
+		return result, nil
+	}
+
+	helper := libpak.NewHelperLayerContributor(context.Buildpack, "spring-cloud-bindings")
+	result.Layers = append(result.Layers, helper)
+	result.BOM.Entries = append(result.BOM.Entries, libcnb.BOMEntry{
+		Name:   "helper",
+		Launch: true,
+	})
+
+	result.Layers = append(result.Layers, NewWebApplicationType(context.Application.Path, manifest.ClassesPath(context.Application.Path)))
+
+	dr, err := libpak.NewDependencyResolver(context)
+	if err != nil {
+		return libcnb.BuildResult{}, fmt.Errorf("unable to create dependency resolver\n%w", err)
+	}
+
+	if dependency, err := dr.Resolve("spring-cloud-bindings", ""); err == nil {
+		dc, err := libpak.NewDependencyCache(context)
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to create dependency cache\n%w", err)
+		}
+
+		result.Layers = append(result.Layers, NewSpringCloudBindingsDependencyLayerContributor(dependency, dc))
+
+		be := dependency.AsBOMEntry()
+		be.Launch = true
+		result.BOM.Entries = append(result.BOM.Entries, be)
+	}
+
+	if layersIndex, ok := manifest["Spring-Boot-Layers-Index"]; slicesSupported && ok {
+		slices, err := NewSlices(context.Application.Path, layersIndex)
+		if err != nil {
+			return libcnb.BuildResult{}, fmt.Errorf("unable to contribute slices\n%w", err)
+		}
+		result.Slices = slices
+	}
+
+	return result, nil
+}
+
+// negotiatePlatformAPI determines which of this buildpack's declared
+// Platform API versions the running platform supports, and reports whether
+// that version is new enough to support slices and the SBOM layer. When the
+// lifecycle hasn't told us which Platform API is in use (CNB_PLATFORM_API is
+// unset, as in unit tests), negotiation is skipped and every feature is
+// enabled.
+func (b Build) negotiatePlatformAPI(context libcnb.BuildContext) (slicesSupported bool, sbomLayerSupported bool, err error) {
+	requested, ok := os.LookupEnv(PlatformAPIEnvVar)
+	if !ok {
+		return true, true, nil
+	}
+
+	supported := b.SupportedPlatformAPIs
+	if len(supported) == 0 && context.Buildpack.API != "" {
+		supported = []string{context.Buildpack.API}
+	}
+	if len(supported) == 0 {
+		return true, true, nil
+	}
+
+	api, err := NegotiatePlatformAPI(requested, supported)
+	if err != nil {
+		return false, false, err
+	}
+
+	return api.AtLeast(SlicesMinimumPlatformAPI), api.AtLeast(SBOMMinimumPlatformAPI), nil
+}
+
+// isNativeImage returns whether the build plan asked for a native-image
+// build, in which case this buildpack contributes labels, BOM entries, the
+// license-metadata and optional SBOM layers, and the native-image layer —
+// there is no fat-jar launch layer to assemble.
+func isNativeImage(plan libcnb.BuildpackPlan) bool {
+	for _, e := range plan.Entries {
+		if e.Name != "spring-boot" {
+			continue
+		}
+
+		if v, ok := e.Metadata["native-image"].(bool); ok && v {
+			return true
+		}
+	}
+
+	return false
+}
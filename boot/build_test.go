@@ -17,6 +17,7 @@
 package boot_test
 
 import (
+	"archive/zip"
 	"io/ioutil"
 	"os"
 	"path/filepath"
@@ -31,6 +32,29 @@ import (
 	"github.com/paketo-buildpacks/spring-boot/boot"
 )
 
+// writeTestJAR creates a jar at path containing a single entry (entryName,
+// entryContents) -- enough for tests that only care about a specific
+// META-INF file a real Maven-built jar would also contain.
+func writeTestJAR(path string, entryName string, entryContents string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+
+	out, err := w.Create(entryName)
+	if err != nil {
+		return err
+	}
+	if _, err := out.Write([]byte(entryContents)); err != nil {
+		return err
+	}
+
+	return w.Close()
+}
+
 func testBuild(t *testing.T, context spec.G, it spec.S) {
 	var (
 		Expect = NewWithT(t).Expect
@@ -125,6 +149,38 @@ Spring-Boot-Lib: BOOT-INF/lib
 		}))
 	})
 
+	it("merges spring-configuration-metadata.json from nested jars into the label and a BOM entry", func() {
+		Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
+Spring-Boot-Version: 1.1.1
+Spring-Boot-Classes: BOOT-INF/classes
+Spring-Boot-Lib: BOOT-INF/lib
+`), 0644)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "spring-configuration-metadata.json"),
+			[]byte(`{ "groups": [ { "name": "alpha", "sourceType": "Application" } ] }`), 0644)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "BOOT-INF", "lib"), 0755)).To(Succeed())
+		Expect(writeTestJAR(
+			filepath.Join(ctx.Application.Path, "BOOT-INF", "lib", "starter-1.0.0.jar"),
+			"META-INF/spring-configuration-metadata.json",
+			`{ "groups": [ { "name": "bravo", "sourceType": "Starter" } ] }`,
+		)).To(Succeed())
+
+		result, err := build.Build(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.Labels).To(ContainElement(libcnb.Label{
+			Key:   "org.springframework.boot.spring-configuration-metadata.json",
+			Value: `{"groups":[{"name":"bravo","sourceType":"Starter"},{"name":"alpha","sourceType":"Application"}]}`,
+		}))
+
+		var found bool
+		for _, entry := range result.BOM.Entries {
+			if entry.Name == "spring-configuration-metadata" {
+				found = true
+			}
+		}
+		Expect(found).To(BeTrue())
+	})
+
 	it("contributes org.opencontainers.image.title label", func() {
 		Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
 Spring-Boot-Version: 1.1.1
@@ -176,11 +232,13 @@ Spring-Boot-Lib: BOOT-INF/lib
 			Name: "dependencies",
 			Metadata: map[string]interface{}{
 				"layer": "application",
-				"dependencies": []libjvm.MavenJAR{
+				"dependencies": []boot.Dependency{
 					{
-						Name:    "test-file",
-						Version: "2.2.2",
-						SHA256:  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+						MavenJAR: libjvm.MavenJAR{
+							Name:    "test-file",
+							Version: "2.2.2",
+							SHA256:  "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+						},
 					},
 				},
 			},
@@ -189,6 +247,144 @@ Spring-Boot-Lib: BOOT-INF/lib
 		}))
 	})
 
+	it("contributes org.opencontainers.image.licenses label and a license-metadata layer", func() {
+		Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
+Spring-Boot-Version: 1.1.1
+Spring-Boot-Classes: BOOT-INF/classes
+Spring-Boot-Lib: BOOT-INF/lib
+`), 0644)).To(Succeed())
+		Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "BOOT-INF", "lib"), 0755)).To(Succeed())
+		Expect(writeTestJAR(
+			filepath.Join(ctx.Application.Path, "BOOT-INF", "lib", "licensed-1.0.0.jar"),
+			"META-INF/maven/test/licensed/pom.xml",
+			`<project><licenses><license><name>Apache License, Version 2.0</name></license></licenses></project>`,
+		)).To(Succeed())
+
+		result, err := build.Build(ctx)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(result.Labels).To(ContainElement(libcnb.Label{
+			Key:   "org.opencontainers.image.licenses",
+			Value: "Apache-2.0",
+		}))
+
+		var names []string
+		for _, layer := range result.Layers {
+			names = append(names, layer.Name())
+		}
+		Expect(names).To(ContainElement("license-metadata"))
+	})
+
+	context("when CNB_PLATFORM_API is set", func() {
+		it.After(func() {
+			Expect(os.Unsetenv("CNB_PLATFORM_API")).To(Succeed())
+		})
+
+		it("errors when the platform API isn't one of the buildpack's declared APIs", func() {
+			Expect(os.Setenv("CNB_PLATFORM_API", "0.8")).To(Succeed())
+			build.SupportedPlatformAPIs = []string{"0.6", "0.7"}
+
+			Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
+Spring-Boot-Version: 1.1.1
+Spring-Boot-Classes: BOOT-INF/classes
+Spring-Boot-Lib: BOOT-INF/lib
+`), 0644)).To(Succeed())
+
+			_, err := build.Build(ctx)
+			Expect(err).To(HaveOccurred())
+		})
+
+		it("does not contribute slices when the negotiated API is below 0.5", func() {
+			Expect(os.Setenv("CNB_PLATFORM_API", "0.4")).To(Succeed())
+			build.SupportedPlatformAPIs = []string{"0.4"}
+
+			Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
+Spring-Boot-Version: 1.1.1
+Spring-Boot-Classes: BOOT-INF/classes
+Spring-Boot-Lib: BOOT-INF/lib
+Spring-Boot-Layers-Index: layers.idx
+`), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "layers.idx"), []byte(`
+- "alpha":
+  - "alpha-1"
+`), 0644)).To(Succeed())
+
+			result, err := build.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Slices).To(HaveLen(0))
+		})
+	})
+
+	context("when BP_SBOM_ENABLED is set", func() {
+		it.Before(func() {
+			Expect(os.Setenv("BP_SBOM_ENABLED", "true")).To(Succeed())
+		})
+
+		it.After(func() {
+			Expect(os.Unsetenv("BP_SBOM_ENABLED")).To(Succeed())
+		})
+
+		it("contributes an sbom layer and a CycloneDX label", func() {
+			Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
+Spring-Boot-Version: 1.1.1
+Spring-Boot-Classes: BOOT-INF/classes
+Spring-Boot-Lib: BOOT-INF/lib
+`), 0644)).To(Succeed())
+
+			result, err := build.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			var names []string
+			for _, layer := range result.Layers {
+				names = append(names, layer.Name())
+			}
+			Expect(names).To(ContainElement("sbom"))
+
+			Expect(result.Labels).To(ContainElement(HaveField("Key", "io.paketo.sbom.cyclonedx")))
+		})
+	})
+
+	context("when a dependency is shadowed by the JDK", func() {
+		it.Before(func() {
+			ctx.Buildpack.Metadata["shadowed-dependencies"] = []map[string]interface{}{
+				{
+					"jdk-distribution": "*",
+					"purl":             "pkg:maven/jakarta.activation/jakarta.activation-api",
+					"provided-by":      "jdk",
+				},
+			}
+		})
+
+		it("drops it from the BOM and records the relationship", func() {
+			Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
+Spring-Boot-Version: 1.1.1
+Spring-Boot-Classes: BOOT-INF/classes
+Spring-Boot-Lib: BOOT-INF/lib
+`), 0644)).To(Succeed())
+			Expect(os.MkdirAll(filepath.Join(ctx.Application.Path, "BOOT-INF", "lib"), 0755)).To(Succeed())
+			Expect(writeTestJAR(
+				filepath.Join(ctx.Application.Path, "BOOT-INF", "lib", "jakarta.activation-api-1.2.1.jar"),
+				"META-INF/maven/jakarta.activation/jakarta.activation-api/pom.properties",
+				"groupId=jakarta.activation\nartifactId=jakarta.activation-api\nversion=1.2.1\n",
+			)).To(Succeed())
+
+			result, err := build.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, entry := range result.BOM.Entries {
+				if entry.Name != "dependencies" {
+					continue
+				}
+				Expect(entry.Metadata["dependencies"]).To(BeEmpty())
+			}
+
+			Expect(result.Labels).To(ContainElement(libcnb.Label{
+				Key:   boot.ShadowedDependenciesLabel,
+				Value: "jakarta.activation-api -> jdk",
+			}))
+		})
+	})
+
 	it("contributes to the result", func() {
 		Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
 Spring-Boot-Version: 1.1.1
@@ -248,7 +444,7 @@ Spring-Boot-Layers-Index: layers.idx
 			})
 		})
 
-		it("adds no layers to the result", func() {
+		it("adds only the native-image caching layer to the result", func() {
 			Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
 Spring-Boot-Version: 1.1.1
 Spring-Boot-Classes: BOOT-INF/classes
@@ -258,7 +454,8 @@ Spring-Boot-Lib: BOOT-INF/lib
 			result, err := build.Build(ctx)
 			Expect(err).NotTo(HaveOccurred())
 
-			Expect(result.Layers).To(HaveLen(0))
+			Expect(result.Layers).To(HaveLen(1))
+			Expect(result.Layers[0].Name()).To(Equal("native-image"))
 		})
 
 		it("adds no slices to the result", func() {
@@ -274,5 +471,22 @@ Spring-Boot-Lib: BOOT-INF/lib
 			Expect(result.Slices).To(HaveLen(0))
 		})
 
+		it("fingerprints BP_NATIVE_IMAGE_BUILD_ARGUMENTS into the layer", func() {
+			Expect(os.Setenv("BP_NATIVE_IMAGE_BUILD_ARGUMENTS", "--no-fallback")).To(Succeed())
+			defer os.Unsetenv("BP_NATIVE_IMAGE_BUILD_ARGUMENTS")
+
+			Expect(ioutil.WriteFile(filepath.Join(ctx.Application.Path, "META-INF", "MANIFEST.MF"), []byte(`
+Spring-Boot-Version: 1.1.1
+Spring-Boot-Classes: BOOT-INF/classes
+Spring-Boot-Lib: BOOT-INF/lib
+`), 0644)).To(Succeed())
+
+			result, err := build.Build(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			layer := result.Layers[0].(boot.NativeImageLayerContributor)
+			Expect(layer.Arguments).To(Equal([]string{"--no-fallback"}))
+		})
+
 	})
 }
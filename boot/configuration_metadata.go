@@ -0,0 +1,301 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/libcnb"
+)
+
+const (
+	configurationMetadataLabel         = "org.springframework.boot.spring-configuration-metadata.json"
+	dataflowConfigurationMetadataLabel = "org.springframework.cloud.dataflow.spring-configuration-metadata.json"
+
+	springConfigurationMetadataFile           = "spring-configuration-metadata.json"
+	additionalSpringConfigurationMetadataFile = "additional-spring-configuration-metadata.json"
+)
+
+// ConfigurationMetadata is the shape of a spring-configuration-metadata.json
+// document, trimmed to the fields the buildpack cares about.
+type ConfigurationMetadata struct {
+	Groups     []map[string]interface{} `json:"groups,omitempty"`
+	Properties []map[string]interface{} `json:"properties,omitempty"`
+	Hints      []map[string]interface{} `json:"hints,omitempty"`
+}
+
+// NewAggregatedConfigurationMetadata merges the application's own
+// META-INF/spring-configuration-metadata.json and
+// META-INF/additional-spring-configuration-metadata.json with the same two
+// files embedded in every jar under libPath, so that configuration keys
+// contributed by starters are visible without cracking every jar. Entries
+// are merged by their "name", last-wins on conflicts; the application's own
+// metadata is merged in last so it can override a starter's defaults.
+func NewAggregatedConfigurationMetadata(applicationPath string, libPath string) (ConfigurationMetadata, error) {
+	var groups, properties, hints []map[string]interface{}
+
+	names, err := jarNames(libPath)
+	if err != nil {
+		return ConfigurationMetadata{}, err
+	}
+
+	for _, name := range names {
+		path := filepath.Join(libPath, name)
+
+		for _, entry := range []string{springConfigurationMetadataFile, additionalSpringConfigurationMetadataFile} {
+			m, err := readJARConfigurationMetadata(path, entry)
+			if err != nil {
+				return ConfigurationMetadata{}, fmt.Errorf("unable to read %s from %s\n%w", entry, name, err)
+			}
+
+			groups = append(groups, m.Groups...)
+			properties = append(properties, m.Properties...)
+			hints = append(hints, m.Hints...)
+		}
+	}
+
+	for _, entry := range []string{springConfigurationMetadataFile, additionalSpringConfigurationMetadataFile} {
+		m, err := readFileConfigurationMetadata(filepath.Join(applicationPath, "META-INF", entry))
+		if err != nil {
+			return ConfigurationMetadata{}, fmt.Errorf("unable to read %s\n%w", entry, err)
+		}
+
+		groups = append(groups, m.Groups...)
+		properties = append(properties, m.Properties...)
+		hints = append(hints, m.Hints...)
+	}
+
+	return ConfigurationMetadata{
+		Groups:     mergeMetadataItems(groups),
+		Properties: mergeMetadataItems(properties),
+		Hints:      mergeMetadataItems(hints),
+	}, nil
+}
+
+// NewConfigurationMetadataLabels contributes the
+// org.springframework.boot.spring-configuration-metadata.json label from the
+// aggregated configuration metadata, and, when a
+// META-INF/dataflow-configuration-metadata.properties file is present,
+// contributes a second org.springframework.cloud.dataflow.* label scoped to
+// the classes that file names.
+func NewConfigurationMetadataLabels(applicationPath string, libPath string) ([]libcnb.Label, ConfigurationMetadata, error) {
+	metadata, err := NewAggregatedConfigurationMetadata(applicationPath, libPath)
+	if err != nil {
+		return nil, ConfigurationMetadata{}, err
+	}
+
+	if len(metadata.Groups) == 0 && len(metadata.Properties) == 0 && len(metadata.Hints) == 0 {
+		return nil, metadata, nil
+	}
+
+	compact, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, ConfigurationMetadata{}, fmt.Errorf("unable to marshal configuration metadata\n%w", err)
+	}
+
+	labels := []libcnb.Label{{Key: configurationMetadataLabel, Value: string(compact)}}
+
+	classes, err := dataflowConfigurationMetadataClasses(applicationPath)
+	if err != nil {
+		return nil, ConfigurationMetadata{}, err
+	}
+	if len(classes) == 0 {
+		return labels, metadata, nil
+	}
+
+	filtered := ConfigurationMetadata{}
+	for _, g := range metadata.Groups {
+		if classes[fmt.Sprintf("%v", g["sourceType"])] {
+			filtered.Groups = append(filtered.Groups, g)
+		}
+	}
+	for _, p := range metadata.Properties {
+		if classes[fmt.Sprintf("%v", p["sourceType"])] {
+			filtered.Properties = append(filtered.Properties, p)
+		}
+	}
+	for _, h := range metadata.Hints {
+		if classes[fmt.Sprintf("%v", h["sourceType"])] {
+			filtered.Hints = append(filtered.Hints, h)
+		}
+	}
+
+	dataflowCompact, err := json.Marshal(filtered)
+	if err != nil {
+		return nil, ConfigurationMetadata{}, fmt.Errorf("unable to marshal dataflow configuration metadata\n%w", err)
+	}
+
+	labels = append(labels, libcnb.Label{Key: dataflowConfigurationMetadataLabel, Value: string(dataflowCompact)})
+
+	return labels, metadata, nil
+}
+
+// NewConfigurationMetadataBOMEntry wraps the aggregated configuration
+// metadata in a BOM entry so that Spring Cloud Data Flow and IDE tooling can
+// discover configuration keys contributed by starters without cracking
+// every jar themselves.
+func NewConfigurationMetadataBOMEntry(metadata ConfigurationMetadata) libcnb.BOMEntry {
+	return libcnb.BOMEntry{
+		Name: "spring-configuration-metadata",
+		Metadata: map[string]interface{}{
+			"configuration-metadata": metadata,
+		},
+		Build:  false,
+		Launch: true,
+	}
+}
+
+// mergeMetadataItems merges a slice of spring-configuration-metadata groups,
+// properties, or hints by their "name", keeping the last occurrence of each
+// name and preserving item order by first occurrence.
+func mergeMetadataItems(items []map[string]interface{}) []map[string]interface{} {
+	if len(items) == 0 {
+		return nil
+	}
+
+	index := map[string]int{}
+	var merged []map[string]interface{}
+
+	for _, item := range items {
+		name, _ := item["name"].(string)
+
+		if i, ok := index[name]; ok {
+			merged[i] = item
+			continue
+		}
+
+		index[name] = len(merged)
+		merged = append(merged, item)
+	}
+
+	return merged
+}
+
+// jarNames lists the jar files directly under libPath, sorted so merge order
+// is deterministic across builds.
+func jarNames(libPath string) ([]string, error) {
+	files, err := ioutil.ReadDir(libPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read %s\n%w", libPath, err)
+	}
+
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), ".jar") {
+			names = append(names, f.Name())
+		}
+	}
+
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// readJARConfigurationMetadata reads a META-INF/*.json configuration
+// metadata file embedded in the jar at jarPath, returning an empty
+// ConfigurationMetadata if either the jar can't be opened (not every file
+// under BOOT-INF/lib is a well-formed jar) or the entry isn't present.
+func readJARConfigurationMetadata(jarPath string, entryName string) (ConfigurationMetadata, error) {
+	r, err := zip.OpenReader(jarPath)
+	if err != nil {
+		return ConfigurationMetadata{}, nil
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.Name != "META-INF/"+entryName {
+			continue
+		}
+
+		in, err := f.Open()
+		if err != nil {
+			return ConfigurationMetadata{}, err
+		}
+		defer in.Close()
+
+		b, err := ioutil.ReadAll(in)
+		if err != nil {
+			return ConfigurationMetadata{}, err
+		}
+
+		var metadata ConfigurationMetadata
+		if err := json.Unmarshal(b, &metadata); err != nil {
+			return ConfigurationMetadata{}, err
+		}
+
+		return metadata, nil
+	}
+
+	return ConfigurationMetadata{}, nil
+}
+
+// readFileConfigurationMetadata reads a configuration metadata file from
+// disk, returning an empty ConfigurationMetadata if it doesn't exist.
+func readFileConfigurationMetadata(path string) (ConfigurationMetadata, error) {
+	b, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return ConfigurationMetadata{}, nil
+	} else if err != nil {
+		return ConfigurationMetadata{}, fmt.Errorf("unable to read %s\n%w", path, err)
+	}
+
+	var metadata ConfigurationMetadata
+	if err := json.Unmarshal(b, &metadata); err != nil {
+		return ConfigurationMetadata{}, fmt.Errorf("unable to unmarshal %s\n%w", path, err)
+	}
+
+	return metadata, nil
+}
+
+// dataflowConfigurationMetadataClasses reads the
+// configuration-properties.classes property from
+// META-INF/dataflow-configuration-metadata.properties, returning the set of
+// class names it names.
+func dataflowConfigurationMetadataClasses(applicationPath string) (map[string]bool, error) {
+	file := filepath.Join(applicationPath, "META-INF", "dataflow-configuration-metadata.properties")
+
+	in, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read %s\n%w", file, err)
+	}
+
+	classes := map[string]bool{}
+	for _, line := range strings.Split(string(in), "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "configuration-properties.classes=") {
+			continue
+		}
+
+		value := strings.TrimPrefix(line, "configuration-properties.classes=")
+		for _, c := range strings.Split(value, ",") {
+			classes[strings.TrimSpace(c)] = true
+		}
+	}
+
+	return classes, nil
+}
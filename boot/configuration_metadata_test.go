@@ -0,0 +1,67 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/spring-boot/boot"
+)
+
+func testConfigurationMetadata(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		applicationPath string
+		libPath         string
+	)
+
+	it.Before(func() {
+		var err error
+		applicationPath, err = ioutil.TempDir("", "configuration-metadata")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(os.MkdirAll(filepath.Join(applicationPath, "META-INF"), 0755)).To(Succeed())
+
+		libPath = filepath.Join(applicationPath, "BOOT-INF", "lib")
+		Expect(os.MkdirAll(libPath, 0755)).To(Succeed())
+	})
+
+	it.After(func() {
+		Expect(os.RemoveAll(applicationPath)).To(Succeed())
+	})
+
+	it("merges groups from nested jars with the application's own, last-wins on a shared name", func() {
+		Expect(ioutil.WriteFile(filepath.Join(applicationPath, "META-INF", "spring-configuration-metadata.json"),
+			[]byte(`{ "groups": [ { "name": "shared", "sourceType": "Application" } ] }`), 0644)).To(Succeed())
+		Expect(writeTestJAR(filepath.Join(libPath, "starter-1.0.0.jar"), "META-INF/spring-configuration-metadata.json",
+			`{ "groups": [ { "name": "shared", "sourceType": "Starter" }, { "name": "only-in-starter" } ] }`)).To(Succeed())
+
+		metadata, err := boot.NewAggregatedConfigurationMetadata(applicationPath, libPath)
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(metadata.Groups).To(HaveLen(2))
+		Expect(metadata.Groups[0]).To(Equal(map[string]interface{}{"name": "shared", "sourceType": "Application"}))
+		Expect(metadata.Groups[1]["name"]).To(Equal("only-in-starter"))
+	})
+}
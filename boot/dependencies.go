@@ -0,0 +1,85 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/paketo-buildpacks/libjvm"
+)
+
+// Dependency describes a single jar packaged under BOOT-INF/lib. It embeds
+// the libjvm.MavenJAR name/version/SHA256 triple and adds the licenses this
+// buildpack was able to recover for it, since libjvm.MavenJAR itself has no
+// notion of licensing.
+type Dependency struct {
+	libjvm.MavenJAR
+	Licenses []string `json:"licenses,omitempty"`
+}
+
+// NewDependenciesBOMEntry walks libPath (BOOT-INF/lib) and builds the
+// "dependencies" BOM entry that describes every jar packaged with the
+// application. The returned dependencies are also handed back to callers
+// (e.g. the shadowed-dependency trim and the license-metadata contribution).
+func NewDependenciesBOMEntry(libPath string) (libcnb.BOMEntry, []Dependency, error) {
+	var dependencies []Dependency
+
+	if _, err := os.Stat(libPath); os.IsNotExist(err) {
+		// no bundled dependencies, still contribute an empty entry
+	} else if err != nil {
+		return libcnb.BOMEntry{}, nil, fmt.Errorf("unable to stat %s\n%w", libPath, err)
+	} else {
+		jars, err := libjvm.NewMavenJARListing(libPath)
+		if err != nil {
+			return libcnb.BOMEntry{}, nil, fmt.Errorf("unable to inspect %s\n%w", libPath, err)
+		}
+
+		for _, jar := range jars {
+			var licenses []string
+
+			// jar.Version is "unknown" when the jar's filename didn't match
+			// libjvm's Maven naming convention, in which case jar.Name is the
+			// full filename and there's no reliable path to reconstruct.
+			if jar.Version != "unknown" {
+				path := filepath.Join(libPath, fmt.Sprintf("%s-%s.jar", jar.Name, jar.Version))
+
+				licenses, err = JARLicenses(path)
+				if err != nil {
+					return libcnb.BOMEntry{}, nil, fmt.Errorf("unable to read licenses from %s\n%w", filepath.Base(path), err)
+				}
+			}
+
+			dependencies = append(dependencies, Dependency{MavenJAR: jar, Licenses: licenses})
+		}
+	}
+
+	sort.Slice(dependencies, func(i, j int) bool { return dependencies[i].Name < dependencies[j].Name })
+
+	return libcnb.BOMEntry{
+		Name: "dependencies",
+		Metadata: map[string]interface{}{
+			"layer":        "application",
+			"dependencies": dependencies,
+		},
+		Build:  false,
+		Launch: true,
+	}, dependencies, nil
+}
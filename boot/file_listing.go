@@ -0,0 +1,89 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FileEntry is a single file's path (relative to the root it was listed
+// from) and content hash.
+type FileEntry struct {
+	Path   string `json:"path"`
+	SHA256 string `json:"sha256"`
+}
+
+// FileListing is a recursive, content-addressed listing of every regular
+// file beneath a root directory, sorted by path so it can be compared for
+// equality across builds regardless of directory-walk ordering.
+type FileListing []FileEntry
+
+// NewFileListing recursively walks root and hashes every regular file it
+// finds.
+func NewFileListing(root string) (FileListing, error) {
+	var listing FileListing
+
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return fmt.Errorf("unable to relativize %s\n%w", path, err)
+		}
+
+		sum, err := sha256File(path)
+		if err != nil {
+			return err
+		}
+
+		listing = append(listing, FileEntry{Path: rel, SHA256: sum})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to walk %s\n%w", root, err)
+	}
+
+	sort.Slice(listing, func(i, j int) bool { return listing[i].Path < listing[j].Path })
+
+	return listing, nil
+}
+
+func sha256File(path string) (string, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("unable to open %s\n%w", path, err)
+	}
+	defer in.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, in); err != nil {
+		return "", fmt.Errorf("unable to hash %s\n%w", path, err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/buildpacks/libcnb"
+	"gopkg.in/yaml.v3"
+)
+
+// NewSlices reads the layered-jar index (Spring-Boot-Layers-Index) and
+// converts each named layer into a libcnb.Slice, so `pack build` and the
+// exporter can reuse unchanged application layers across builds.
+func NewSlices(applicationPath string, layersIndex string) ([]libcnb.Slice, error) {
+	if layersIndex == "" {
+		return nil, nil
+	}
+
+	file := filepath.Join(applicationPath, layersIndex)
+
+	in, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read %s\n%w", file, err)
+	}
+
+	var index []map[string][]string
+	if err := yaml.Unmarshal(in, &index); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal %s\n%w", file, err)
+	}
+
+	var slices []libcnb.Slice
+	for _, layer := range index {
+		for _, paths := range layer {
+			slices = append(slices, libcnb.Slice{Paths: paths})
+		}
+	}
+
+	return slices, nil
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/buildpacks/libcnb"
+
+	"github.com/paketo-buildpacks/spring-boot/boot/sbom"
+)
+
+// LicenseMetadataLayerContributor writes a license-metadata layer containing
+// a JSON index of every dependency's recovered licenses, keyed by purl, so
+// license-compliance tooling can inspect the image without re-opening every
+// jar.
+type LicenseMetadataLayerContributor struct {
+	Dependencies []Dependency
+	Components   []sbom.Component
+}
+
+// NewLicenseMetadataLayerContributor creates a new
+// LicenseMetadataLayerContributor. components supplies the groupId that
+// libjvm.MavenJAR (and so Dependency) doesn't carry, matched to a
+// dependency the same way TrimShadowedDependencies does: by artifact name.
+func NewLicenseMetadataLayerContributor(dependencies []Dependency, components []sbom.Component) LicenseMetadataLayerContributor {
+	return LicenseMetadataLayerContributor{Dependencies: dependencies, Components: components}
+}
+
+func (l LicenseMetadataLayerContributor) Contribute(layer libcnb.Layer) (libcnb.Layer, error) {
+	groupIDByArtifactID := map[string]string{}
+	for _, c := range l.Components {
+		groupIDByArtifactID[c.ArtifactID] = c.GroupID
+	}
+
+	index := map[string][]string{}
+	for _, d := range l.Dependencies {
+		group, ok := groupIDByArtifactID[d.Name]
+		if !ok {
+			group = d.Name
+		}
+
+		purl := fmt.Sprintf("pkg:maven/%s/%s@%s", group, d.Name, d.Version)
+		index[purl] = d.Licenses
+	}
+
+	b, err := json.MarshalIndent(index, "", "  ")
+	if err != nil {
+		return libcnb.Layer{}, fmt.Errorf("unable to marshal license index\n%w", err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(layer.Path, "licenses.json"), b, 0644); err != nil {
+		return libcnb.Layer{}, fmt.Errorf("unable to write license index\n%w", err)
+	}
+
+	layer.LayerTypes = libcnb.LayerTypes{Launch: true}
+
+	return layer, nil
+}
+
+func (LicenseMetadataLayerContributor) Name() string {
+	return "license-metadata"
+}
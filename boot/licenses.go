@@ -0,0 +1,114 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/paketo-buildpacks/spring-boot/boot/sbom"
+)
+
+// spdxIDHeader is the "SPDX-License-Identifier: <id>" header some projects
+// embed at the top of their LICENSE file, per the REUSE/SPDX convention.
+const spdxIDHeader = "SPDX-License-Identifier:"
+
+// JARLicenses recovers the licenses declared by the jar at path, preferring
+// the structured <licenses> element of an embedded pom.xml and falling back
+// to a META-INF/LICENSE* or META-INF/NOTICE* file's SPDX-License-Identifier
+// header when no embedded pom.xml declares any license.
+func JARLicenses(path string) ([]string, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		// not every file under BOOT-INF/lib is a well-formed jar (tests use
+		// empty placeholder files); treat it as simply having no licenses
+		// to recover rather than failing the whole build.
+		return nil, nil
+	}
+	defer r.Close()
+
+	var pomLicenses []string
+	var headerLicenses []string
+
+	for _, f := range r.File {
+		switch {
+		case strings.HasPrefix(f.Name, "META-INF/maven/") && strings.HasSuffix(f.Name, "/pom.xml"):
+			pomLicenses, err = sbom.ReadPomXMLLicenses(f)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read %s\n%w", f.Name, err)
+			}
+		case strings.HasPrefix(f.Name, "META-INF/LICENSE") || strings.HasPrefix(f.Name, "META-INF/NOTICE"):
+			if id, ok, err := readSPDXHeader(f); err != nil {
+				return nil, fmt.Errorf("unable to read %s\n%w", f.Name, err)
+			} else if ok {
+				headerLicenses = append(headerLicenses, id)
+			}
+		}
+	}
+
+	if len(pomLicenses) > 0 {
+		return pomLicenses, nil
+	}
+
+	return headerLicenses, nil
+}
+
+func readSPDXHeader(f *zip.File) (string, bool, error) {
+	in, err := f.Open()
+	if err != nil {
+		return "", false, err
+	}
+	defer in.Close()
+
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, spdxIDHeader) {
+			return strings.TrimSpace(strings.TrimPrefix(line, spdxIDHeader)), true, nil
+		}
+	}
+
+	return "", false, scanner.Err()
+}
+
+// AggregateLicenseExpression builds the org.opencontainers.image.licenses
+// value: every license recovered across dependencies, resolved to an SPDX
+// identifier where recognized, deduplicated and joined with " AND ".
+func AggregateLicenseExpression(dependencies []Dependency) string {
+	seen := map[string]bool{}
+	var expressions []string
+
+	for _, d := range dependencies {
+		for _, l := range d.Licenses {
+			id, ok := sbom.SPDXIdentifier(l)
+			if !ok {
+				id = l
+			}
+
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			expressions = append(expressions, id)
+		}
+	}
+
+	return strings.Join(expressions, " AND ")
+}
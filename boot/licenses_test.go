@@ -0,0 +1,89 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/paketo-buildpacks/libjvm"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/spring-boot/boot"
+)
+
+func testLicenses(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		dir string
+	)
+
+	it.Before(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "licenses")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	it.After(func() {
+		Expect(os.RemoveAll(dir)).To(Succeed())
+	})
+
+	context("JARLicenses", func() {
+		it("recovers licenses from an embedded pom.xml", func() {
+			path := filepath.Join(dir, "test.jar")
+			Expect(writeTestJAR(path, "META-INF/maven/test/test/pom.xml",
+				`<project><licenses><license><name>MIT License</name></license></licenses></project>`)).To(Succeed())
+
+			licenses, err := boot.JARLicenses(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"MIT License"}))
+		})
+
+		it("falls back to an SPDX-License-Identifier header", func() {
+			path := filepath.Join(dir, "test.jar")
+			Expect(writeTestJAR(path, "META-INF/LICENSE", "SPDX-License-Identifier: Apache-2.0\n")).To(Succeed())
+
+			licenses, err := boot.JARLicenses(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(Equal([]string{"Apache-2.0"}))
+		})
+
+		it("returns no licenses for a file that isn't a jar", func() {
+			path := filepath.Join(dir, "test.jar")
+			Expect(ioutil.WriteFile(path, []byte{}, 0644)).To(Succeed())
+
+			licenses, err := boot.JARLicenses(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(licenses).To(BeEmpty())
+		})
+	})
+
+	context("AggregateLicenseExpression", func() {
+		it("deduplicates and resolves SPDX identifiers", func() {
+			expression := boot.AggregateLicenseExpression([]boot.Dependency{
+				{MavenJAR: libjvm.MavenJAR{Name: "alpha"}, Licenses: []string{"Apache License, Version 2.0"}},
+				{MavenJAR: libjvm.MavenJAR{Name: "bravo"}, Licenses: []string{"Apache License, Version 2.0", "MIT License"}},
+			})
+
+			Expect(expression).To(Equal("Apache-2.0 AND MIT"))
+		})
+	})
+}
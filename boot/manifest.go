@@ -0,0 +1,96 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Manifest is the parsed contents of an application's META-INF/MANIFEST.MF.
+type Manifest map[string]string
+
+// NewManifest reads and parses the Java manifest at
+// <application>/META-INF/MANIFEST.MF. An application without a manifest
+// yields an empty Manifest rather than an error, since the buildpack treats
+// a missing manifest as "not a Spring Boot application".
+func NewManifest(applicationPath string) (Manifest, error) {
+	file := filepath.Join(applicationPath, "META-INF", "MANIFEST.MF")
+
+	in, err := os.Open(file)
+	if os.IsNotExist(err) {
+		return Manifest{}, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to open %s\n%w", file, err)
+	}
+	defer in.Close()
+
+	manifest := Manifest{}
+	scanner := bufio.NewScanner(in)
+
+	var key string
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if line == "" {
+			continue
+		}
+
+		// continuation lines start with a single space
+		if strings.HasPrefix(line, " ") && key != "" {
+			manifest[key] += strings.TrimPrefix(line, " ")
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		key = strings.TrimSpace(parts[0])
+		manifest[key] = strings.TrimSpace(parts[1])
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to scan %s\n%w", file, err)
+	}
+
+	return manifest, nil
+}
+
+// ClassesPath returns the application's classes directory, defaulting to
+// BOOT-INF/classes when the manifest does not declare one.
+func (m Manifest) ClassesPath(applicationPath string) string {
+	classes := m["Spring-Boot-Classes"]
+	if classes == "" {
+		classes = filepath.Join("BOOT-INF", "classes")
+	}
+	return filepath.Join(applicationPath, classes)
+}
+
+// LibPath returns the application's library directory, defaulting to
+// BOOT-INF/lib when the manifest does not declare one.
+func (m Manifest) LibPath(applicationPath string) string {
+	lib := m["Spring-Boot-Lib"]
+	if lib == "" {
+		lib = filepath.Join("BOOT-INF", "lib")
+	}
+	return filepath.Join(applicationPath, lib)
+}
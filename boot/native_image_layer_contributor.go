@@ -0,0 +1,93 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/mattn/go-shellwords"
+	"github.com/paketo-buildpacks/libpak"
+	"github.com/paketo-buildpacks/libpak/bard"
+)
+
+// NativeImageBuildArgumentsEnvVar lets users pass additional arguments
+// (shellwords-parsed, as on a command line) to the native-image executable.
+const NativeImageBuildArgumentsEnvVar = "BP_NATIVE_IMAGE_BUILD_ARGUMENTS"
+
+// NativeImageLayerContributor fingerprints the native-image build's inputs
+// (its arguments plus a content-addressed listing of the application
+// sources) and only re-invokes the native-image executable when that
+// fingerprint changes, so that an unchanged `pack build` reuses the
+// previously compiled executable instead of recompiling it from scratch.
+type NativeImageLayerContributor struct {
+	LayerContributor libpak.LayerContributor
+	ApplicationPath  string
+	Arguments        []string
+	Executable       string
+	Logger           bard.Logger
+}
+
+// NewNativeImageLayerContributor creates a new NativeImageLayerContributor,
+// parsing BP_NATIVE_IMAGE_BUILD_ARGUMENTS and fingerprinting applicationPath.
+func NewNativeImageLayerContributor(applicationPath string, executable string, logger bard.Logger) (NativeImageLayerContributor, error) {
+	arguments, err := shellwords.Parse(os.Getenv(NativeImageBuildArgumentsEnvVar))
+	if err != nil {
+		return NativeImageLayerContributor{}, fmt.Errorf("unable to parse %s\n%w", NativeImageBuildArgumentsEnvVar, err)
+	}
+
+	files, err := NewFileListing(applicationPath)
+	if err != nil {
+		return NativeImageLayerContributor{}, fmt.Errorf("unable to list %s\n%w", applicationPath, err)
+	}
+
+	expected := map[string]interface{}{"arguments": arguments, "files": files}
+
+	return NativeImageLayerContributor{
+		LayerContributor: libpak.NewLayerContributor("native-image", expected, libcnb.LayerTypes{Build: true, Cache: true, Launch: true}),
+		ApplicationPath:  applicationPath,
+		Arguments:        arguments,
+		Executable:       executable,
+		Logger:           logger,
+	}, nil
+}
+
+func (n NativeImageLayerContributor) Contribute(layer libcnb.Layer) (libcnb.Layer, error) {
+	return n.LayerContributor.Contribute(layer, func() (libcnb.Layer, error) {
+		n.Logger.Bodyf("Compiling native image (inputs changed, this may take a while)")
+
+		arguments := append(append([]string{}, n.Arguments...), "-H:Name="+filepath.Join(layer.Path, n.Executable))
+
+		cmd := exec.Command("native-image", arguments...)
+		cmd.Dir = n.ApplicationPath
+		cmd.Stdout = n.Logger.BodyWriter()
+		cmd.Stderr = n.Logger.BodyWriter()
+
+		if err := cmd.Run(); err != nil {
+			return libcnb.Layer{}, fmt.Errorf("unable to run native-image\n%w", err)
+		}
+
+		return layer, nil
+	})
+}
+
+func (NativeImageLayerContributor) Name() string {
+	return "native-image"
+}
@@ -0,0 +1,127 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	// SlicesMinimumPlatformAPI is the lowest Platform API that supports
+	// libcnb.Slice, below which the buildpack must not contribute slices.
+	SlicesMinimumPlatformAPI = "0.5"
+
+	// SBOMMinimumPlatformAPI is the lowest Platform API that supports
+	// attaching an SBOM layer to the build result.
+	SBOMMinimumPlatformAPI = "0.7"
+)
+
+// PlatformAPI is a parsed major.minor CNB Platform API version.
+type PlatformAPI struct {
+	Major int
+	Minor int
+}
+
+// ParsePlatformAPI parses a "major.minor" (or bare "major") Platform API
+// version string.
+func ParsePlatformAPI(version string) (PlatformAPI, error) {
+	parts := strings.SplitN(version, ".", 2)
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return PlatformAPI{}, fmt.Errorf("unable to parse Platform API major version %q\n%w", version, err)
+	}
+
+	var minor int
+	if len(parts) == 2 {
+		minor, err = strconv.Atoi(parts[1])
+		if err != nil {
+			return PlatformAPI{}, fmt.Errorf("unable to parse Platform API minor version %q\n%w", version, err)
+		}
+	}
+
+	return PlatformAPI{Major: major, Minor: minor}, nil
+}
+
+func (p PlatformAPI) String() string {
+	return fmt.Sprintf("%d.%d", p.Major, p.Minor)
+}
+
+// Supports returns whether p satisfies a requested Platform API: the major
+// versions must match exactly, and p's minor version must be at least the
+// requested minor version.
+func (p PlatformAPI) Supports(requested PlatformAPI) bool {
+	return p.Major == requested.Major && p.Minor >= requested.Minor
+}
+
+// AtLeast returns whether p is greater than or equal to the "major.minor"
+// version string other.
+func (p PlatformAPI) AtLeast(other string) bool {
+	o, err := ParsePlatformAPI(other)
+	if err != nil {
+		return false
+	}
+
+	if p.Major != o.Major {
+		return p.Major > o.Major
+	}
+
+	return p.Minor >= o.Minor
+}
+
+// NegotiatePlatformAPI picks the highest version in supported that the
+// platform (requested) also supports, following the same major-match /
+// minor-at-least scheme the lifecycle itself uses to match a buildpack's
+// declared `api` against `CNB_PLATFORM_API`. It returns an error naming both
+// sides when no declared version is acceptable to the platform.
+func NegotiatePlatformAPI(requested string, supported []string) (PlatformAPI, error) {
+	r, err := ParsePlatformAPI(requested)
+	if err != nil {
+		return PlatformAPI{}, err
+	}
+
+	if len(supported) == 0 {
+		return PlatformAPI{}, fmt.Errorf("buildpack does not declare any supported Platform APIs")
+	}
+
+	parsed := make([]PlatformAPI, 0, len(supported))
+	for _, s := range supported {
+		p, err := ParsePlatformAPI(s)
+		if err != nil {
+			return PlatformAPI{}, err
+		}
+		parsed = append(parsed, p)
+	}
+
+	sort.Slice(parsed, func(i, j int) bool {
+		if parsed[i].Major != parsed[j].Major {
+			return parsed[i].Major > parsed[j].Major
+		}
+		return parsed[i].Minor > parsed[j].Minor
+	})
+
+	for _, p := range parsed {
+		if p.Supports(r) {
+			return p, nil
+		}
+	}
+
+	return PlatformAPI{}, fmt.Errorf("platform API %s is not supported by any of this buildpack's declared APIs %s", requested, strings.Join(supported, ", "))
+}
@@ -0,0 +1,68 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/spring-boot/boot"
+)
+
+func testPlatformAPI(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	context("NegotiatePlatformAPI", func() {
+		it("picks the highest declared version the platform supports", func() {
+			api, err := boot.NegotiatePlatformAPI("0.6", []string{"0.4", "0.5", "0.6", "0.7"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(api.String()).To(Equal("0.7"))
+		})
+
+		it("supports a higher declared minor than requested", func() {
+			api, err := boot.NegotiatePlatformAPI("0.5", []string{"0.7"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(api.String()).To(Equal("0.7"))
+		})
+
+		it("errors when no declared API satisfies the platform", func() {
+			_, err := boot.NegotiatePlatformAPI("0.8", []string{"0.6", "0.7"})
+			Expect(err).To(HaveOccurred())
+		})
+
+		it("errors on a major version mismatch", func() {
+			_, err := boot.NegotiatePlatformAPI("1.0", []string{"0.7"})
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	context("PlatformAPI.AtLeast", func() {
+		it("is true when the minor version is newer", func() {
+			api, err := boot.ParsePlatformAPI("0.7")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(api.AtLeast("0.5")).To(BeTrue())
+		})
+
+		it("is false when the minor version is older", func() {
+			api, err := boot.ParsePlatformAPI("0.4")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(api.AtLeast("0.5")).To(BeFalse())
+		})
+	})
+}
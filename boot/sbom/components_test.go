@@ -0,0 +1,216 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/buildpacks/libcnb"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/spring-boot/boot/sbom"
+)
+
+// buildJAR returns the bytes of a jar containing entries, keyed by name.
+// Used both to write jars directly under a test libPath and to embed one
+// jar's bytes as a BOOT-INF/lib/*.jar entry of another.
+func buildJAR(entries map[string]string) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	w := zip.NewWriter(buf)
+
+	for name, contents := range entries {
+		out, err := w.Create(name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := out.Write([]byte(contents)); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func writeJAR(path string, entries map[string]string) error {
+	b, err := buildJAR(entries)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, b, 0644)
+}
+
+func testComponents(t *testing.T, context spec.G, it spec.S) {
+	var (
+		Expect = NewWithT(t).Expect
+
+		libPath string
+	)
+
+	it.Before(func() {
+		var err error
+		libPath, err = ioutil.TempDir("", "sbom-components")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	it.After(func() {
+		Expect(os.RemoveAll(libPath)).To(Succeed())
+	})
+
+	context("NewComponents", func() {
+		it("recovers Maven coordinates and licenses from a jar's pom.properties and pom.xml", func() {
+			Expect(writeJAR(filepath.Join(libPath, "example-core-1.2.3.jar"), map[string]string{
+				"META-INF/maven/com.example/example-core/pom.properties": "# comment\n\ngroupId=com.example\nartifactId=example-core\nversion=1.2.3\n",
+				"META-INF/maven/com.example/example-core/pom.xml":        `<project><licenses><license><name>Apache License, Version 2.0</name></license></licenses></project>`,
+			})).To(Succeed())
+
+			components, err := sbom.NewComponents(libPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(components).To(HaveLen(1))
+
+			c := components[0]
+			Expect(c.GroupID).To(Equal("com.example"))
+			Expect(c.ArtifactID).To(Equal("example-core"))
+			Expect(c.Version).To(Equal("1.2.3"))
+			Expect(c.PURL).To(Equal("pkg:maven/com.example/example-core@1.2.3"))
+			Expect(c.Licenses).To(Equal([]string{"Apache License, Version 2.0"}))
+			Expect(c.SHA1).NotTo(BeEmpty())
+			Expect(c.SHA256).NotTo(BeEmpty())
+		})
+
+		it("recovers components from jars nested under BOOT-INF/lib", func() {
+			nested, err := buildJAR(map[string]string{
+				"META-INF/maven/com.example/example-nested/pom.properties": "groupId=com.example\nartifactId=example-nested\nversion=4.5.6\n",
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(writeJAR(filepath.Join(libPath, "example-outer-1.0.0.jar"), map[string]string{
+				"META-INF/maven/com.example/example-outer/pom.properties": "groupId=com.example\nartifactId=example-outer\nversion=1.0.0\n",
+				"BOOT-INF/lib/example-nested-4.5.6.jar":                   string(nested),
+			})).To(Succeed())
+
+			components, err := sbom.NewComponents(libPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(components).To(HaveLen(2))
+
+			// sorted by purl
+			Expect(components[0].ArtifactID).To(Equal("example-nested"))
+			Expect(components[1].ArtifactID).To(Equal("example-outer"))
+		})
+
+		it("skips jars with no embedded Maven coordinates", func() {
+			Expect(writeJAR(filepath.Join(libPath, "application.jar"), map[string]string{
+				"Application.class": "not a real class file, just test content",
+			})).To(Succeed())
+
+			components, err := sbom.NewComponents(libPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(components).To(BeEmpty())
+		})
+
+		it("skips files under BOOT-INF/lib that aren't well-formed jars", func() {
+			Expect(ioutil.WriteFile(filepath.Join(libPath, "broken.jar"), []byte("not a jar"), 0644)).To(Succeed())
+
+			components, err := sbom.NewComponents(libPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(components).To(BeEmpty())
+		})
+
+		it("returns no components when libPath doesn't exist", func() {
+			components, err := sbom.NewComponents(filepath.Join(libPath, "missing"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(components).To(BeEmpty())
+		})
+	})
+
+	context("LayerContributor", func() {
+		it("writes a CycloneDX and an SPDX document into the layer", func() {
+			components := []sbom.Component{
+				{
+					GroupID:    "com.example",
+					ArtifactID: "example-core",
+					Version:    "1.2.3",
+					PURL:       "pkg:maven/com.example/example-core@1.2.3",
+					SHA1:       "aaaa",
+					SHA256:     "bbbb",
+					Licenses:   []string{"Apache License, Version 2.0"},
+				},
+			}
+
+			layerPath, err := ioutil.TempDir("", "sbom-layer")
+			Expect(err).NotTo(HaveOccurred())
+			defer os.RemoveAll(layerPath)
+
+			contributor := sbom.NewLayerContributor(components)
+			Expect(contributor.Name()).To(Equal("sbom"))
+
+			layer, err := contributor.Contribute(libcnb.Layer{Path: layerPath})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(layer.LayerTypes.Launch).To(BeTrue())
+
+			cdx, err := ioutil.ReadFile(filepath.Join(layerPath, "bom.cdx.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(cdx)).To(ContainSubstring(`"purl": "pkg:maven/com.example/example-core@1.2.3"`))
+
+			spdx, err := ioutil.ReadFile(filepath.Join(layerPath, "bom.spdx.json"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(spdx)).To(ContainSubstring(`"name": "example-core"`))
+
+			label := contributor.Label(layerPath)
+			Expect(label.Key).To(Equal(sbom.CycloneDXLabel))
+			Expect(label.Value).To(Equal(filepath.Join(layerPath, "bom.cdx.json")))
+		})
+	})
+
+	context("NewSPDXDocument", func() {
+		it("carries an SPDX identifier and a purl external ref for each component", func() {
+			doc := sbom.NewSPDXDocument("application", []sbom.Component{
+				{
+					ArtifactID: "example-core",
+					Version:    "1.2.3",
+					PURL:       "pkg:maven/com.example/example-core@1.2.3",
+					SHA1:       "aaaa",
+					SHA256:     "bbbb",
+					Licenses:   []string{"Apache License, Version 2.0"},
+				},
+			})
+
+			Expect(doc.SPDXVersion).To(Equal("SPDX-2.3"))
+			Expect(doc.Name).To(Equal("application"))
+			Expect(doc.Packages).To(HaveLen(1))
+			Expect(doc.Packages[0].Name).To(Equal("example-core"))
+			Expect(doc.Packages[0].LicenseConcluded).To(Equal("Apache-2.0"))
+			Expect(doc.Packages[0].ExternalRefs[0].ReferenceLocator).To(Equal("pkg:maven/com.example/example-core@1.2.3"))
+		})
+
+		it("falls back to NOASSERTION when no license was recovered", func() {
+			doc := sbom.NewSPDXDocument("application", []sbom.Component{{ArtifactID: "example-core"}})
+
+			Expect(doc.Packages[0].LicenseConcluded).To(Equal("NOASSERTION"))
+		})
+	})
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+// CycloneDXDocument is a minimal CycloneDX 1.4 BOM, containing only the
+// fields the buildpack populates.
+type CycloneDXDocument struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Components  []CycloneDXComponent `json:"components"`
+}
+
+// CycloneDXComponent is a single CycloneDX "library" component.
+type CycloneDXComponent struct {
+	Type    string             `json:"type"`
+	Group   string             `json:"group,omitempty"`
+	Name    string             `json:"name"`
+	Version string             `json:"version"`
+	PURL    string             `json:"purl"`
+	Hashes  []CycloneDXHash    `json:"hashes"`
+	License []CycloneDXLicense `json:"licenses,omitempty"`
+}
+
+// CycloneDXHash is a single content hash of a component.
+type CycloneDXHash struct {
+	Algorithm string `json:"alg"`
+	Content   string `json:"content"`
+}
+
+// CycloneDXLicense wraps a single license identifier or name.
+type CycloneDXLicense struct {
+	License CycloneDXLicenseID `json:"license"`
+}
+
+// CycloneDXLicenseID is an SPDX identifier when one is recognized, otherwise
+// a free-form name.
+type CycloneDXLicenseID struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+// NewCycloneDXDocument converts components into a CycloneDX 1.4 document.
+func NewCycloneDXDocument(components []Component) CycloneDXDocument {
+	doc := CycloneDXDocument{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.4",
+		Version:     1,
+	}
+
+	for _, c := range components {
+		component := CycloneDXComponent{
+			Type:    "library",
+			Group:   c.GroupID,
+			Name:    c.ArtifactID,
+			Version: c.Version,
+			PURL:    c.PURL,
+			Hashes: []CycloneDXHash{
+				{Algorithm: "SHA-1", Content: c.SHA1},
+				{Algorithm: "SHA-256", Content: c.SHA256},
+			},
+		}
+
+		for _, l := range c.Licenses {
+			if id, ok := SPDXIdentifier(l); ok {
+				component.License = append(component.License, CycloneDXLicense{License: CycloneDXLicenseID{ID: id}})
+			} else {
+				component.License = append(component.License, CycloneDXLicense{License: CycloneDXLicenseID{Name: l}})
+			}
+		}
+
+		doc.Components = append(doc.Components, component)
+	}
+
+	return doc
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/buildpacks/libcnb"
+)
+
+// LayerContributor writes a CycloneDX and an SPDX rendering of Components
+// into a dedicated "sbom" layer.
+type LayerContributor struct {
+	Name_      string
+	Components []Component
+}
+
+// NewLayerContributor creates a new LayerContributor.
+func NewLayerContributor(components []Component) LayerContributor {
+	return LayerContributor{Name_: "sbom", Components: components}
+}
+
+func (l LayerContributor) Contribute(layer libcnb.Layer) (libcnb.Layer, error) {
+	cyclonedx, err := json.MarshalIndent(NewCycloneDXDocument(l.Components), "", "  ")
+	if err != nil {
+		return libcnb.Layer{}, fmt.Errorf("unable to marshal CycloneDX document\n%w", err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(layer.Path, "bom.cdx.json"), cyclonedx, 0644); err != nil {
+		return libcnb.Layer{}, fmt.Errorf("unable to write CycloneDX document\n%w", err)
+	}
+
+	spdx, err := json.MarshalIndent(NewSPDXDocument("application", l.Components), "", "  ")
+	if err != nil {
+		return libcnb.Layer{}, fmt.Errorf("unable to marshal SPDX document\n%w", err)
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(layer.Path, "bom.spdx.json"), spdx, 0644); err != nil {
+		return libcnb.Layer{}, fmt.Errorf("unable to write SPDX document\n%w", err)
+	}
+
+	layer.LayerTypes = libcnb.LayerTypes{Build: false, Launch: true, Cache: false}
+
+	return layer, nil
+}
+
+func (l LayerContributor) Name() string {
+	return l.Name_
+}
+
+// Label builds the io.paketo.sbom.cyclonedx OCI label, pointing at the
+// bom.cdx.json document Contribute writes into the layer at layerPath
+// rather than inlining it, since the document has no bound on size.
+func (l LayerContributor) Label(layerPath string) libcnb.Label {
+	return libcnb.Label{Key: CycloneDXLabel, Value: filepath.Join(layerPath, "bom.cdx.json")}
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import "strings"
+
+// commonSPDXIdentifiers maps the license names most frequently found in a
+// pom.xml <license><name> element to their SPDX identifier. It is
+// intentionally small; anything not recognized is carried through as a raw
+// name rather than guessed at.
+var commonSPDXIdentifiers = map[string]string{
+	"the apache software license, version 2.0": "Apache-2.0",
+	"apache license 2.0":                       "Apache-2.0",
+	"apache license, version 2.0":              "Apache-2.0",
+	"mit license":                              "MIT",
+	"the mit license":                          "MIT",
+	"eclipse public license - v 2.0":           "EPL-2.0",
+	"eclipse public license v2.0":              "EPL-2.0",
+	"bsd license 3":                            "BSD-3-Clause",
+	"gnu lesser general public license":        "LGPL-2.1-or-later",
+}
+
+// SPDXIdentifier returns the SPDX identifier for a raw license name, when
+// one of the common licenses is recognized.
+func SPDXIdentifier(name string) (string, bool) {
+	id, ok := commonSPDXIdentifiers[strings.ToLower(strings.TrimSpace(name))]
+	return id, ok
+}
+
+// SPDXExpression joins a set of license names into a single SPDX expression,
+// preferring identifiers over raw names and de-duplicating.
+func SPDXExpression(licenses []string) string {
+	seen := map[string]bool{}
+	var parts []string
+
+	for _, l := range licenses {
+		id, ok := SPDXIdentifier(l)
+		if !ok {
+			id = l
+		}
+
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		parts = append(parts, id)
+	}
+
+	return strings.Join(parts, " AND ")
+}
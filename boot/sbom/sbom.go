@@ -0,0 +1,265 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package sbom contributes a standards-based Software Bill of Materials for
+// a Spring Boot application, alongside the buildpack-specific libcnb.BOMEntry
+// that the rest of the boot package already produces. The libcnb BOM is
+// sufficient for the lifecycle, but it isn't something Syft, Grype, or other
+// supply-chain tooling understands, so this package additionally emits a
+// CycloneDX 1.4 document and an SPDX 2.3 alternate into a dedicated layer.
+package sbom
+
+import (
+	"archive/zip"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/buildpacks/libcnb"
+)
+
+// EnabledEnvVar is the environment variable users set to request the
+// standards-based SBOM in addition to the libcnb BOM.
+const EnabledEnvVar = "BP_SBOM_ENABLED"
+
+// CycloneDXLabel is the OCI label the CycloneDX document is referenced from.
+const CycloneDXLabel = "io.paketo.sbom.cyclonedx"
+
+// Component is a single dependency recovered from a jar under BOOT-INF/lib,
+// normalized to the fields CycloneDX and SPDX both need.
+type Component struct {
+	GroupID    string   `json:"group"`
+	ArtifactID string   `json:"artifact"`
+	Version    string   `json:"version"`
+	PURL       string   `json:"purl"`
+	SHA1       string   `json:"sha1"`
+	SHA256     string   `json:"sha256"`
+	Licenses   []string `json:"licenses,omitempty"`
+}
+
+// Enabled returns whether the SBOM should be contributed, either because the
+// user set BP_SBOM_ENABLED or because the build plan was given an "sbom"
+// entry by a later buildpack in the group.
+func Enabled(plan libcnb.BuildpackPlan) bool {
+	if v, ok := os.LookupEnv(EnabledEnvVar); ok {
+		return v == "true"
+	}
+
+	for _, e := range plan.Entries {
+		if e.Name == "sbom" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NewComponents walks libPath (BOOT-INF/lib), and the nested jars within it,
+// recovering Maven coordinates from embedded META-INF/maven/*/pom.properties
+// and licenses from the companion pom.xml.
+func NewComponents(libPath string) ([]Component, error) {
+	files, err := ioutil.ReadDir(libPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("unable to read %s\n%w", libPath, err)
+	}
+
+	var components []Component
+	for _, file := range files {
+		if file.IsDir() || !strings.HasSuffix(file.Name(), ".jar") {
+			continue
+		}
+
+		path := filepath.Join(libPath, file.Name())
+		c, err := NewComponent(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to inspect %s\n%w", path, err)
+		}
+
+		components = append(components, c...)
+	}
+
+	sort.Slice(components, func(i, j int) bool { return components[i].PURL < components[j].PURL })
+
+	return components, nil
+}
+
+// NewComponent recovers every Maven component embedded in the jar at path,
+// including components embedded in jars nested beneath it.
+func NewComponent(path string) ([]Component, error) {
+	r, err := zip.OpenReader(path)
+	if err != nil {
+		// not every file under BOOT-INF/lib is guaranteed to be a well-formed jar
+		return nil, nil
+	}
+	defer r.Close()
+
+	sha1Sum, sha256Sum, err := hashes(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var components []Component
+	var properties map[string]string
+	var licenses []string
+
+	for _, f := range r.File {
+		switch {
+		case strings.HasPrefix(f.Name, "META-INF/maven/") && strings.HasSuffix(f.Name, "/pom.properties"):
+			properties, err = readPomProperties(f)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read %s\n%w", f.Name, err)
+			}
+		case strings.HasPrefix(f.Name, "META-INF/maven/") && strings.HasSuffix(f.Name, "/pom.xml"):
+			licenses, err = ReadPomXMLLicenses(f)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read %s\n%w", f.Name, err)
+			}
+		case strings.HasPrefix(f.Name, "BOOT-INF/lib/") && strings.HasSuffix(f.Name, ".jar"):
+			nested, err := readNestedComponent(f)
+			if err != nil {
+				return nil, fmt.Errorf("unable to read nested jar %s\n%w", f.Name, err)
+			}
+			components = append(components, nested...)
+		}
+	}
+
+	if properties == nil {
+		// not every jar under BOOT-INF/lib is a Maven artifact (e.g. the
+		// application's own re-packaged classes); skip components we can't
+		// attribute to a coordinate rather than guessing.
+		return components, nil
+	}
+
+	group, artifact, version := properties["groupId"], properties["artifactId"], properties["version"]
+	components = append(components, Component{
+		GroupID:    group,
+		ArtifactID: artifact,
+		Version:    version,
+		PURL:       fmt.Sprintf("pkg:maven/%s/%s@%s", group, artifact, version),
+		SHA1:       sha1Sum,
+		SHA256:     sha256Sum,
+		Licenses:   licenses,
+	})
+
+	return components, nil
+}
+
+func readNestedComponent(f *zip.File) ([]Component, error) {
+	in, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	tmp, err := ioutil.TempFile("", "nested-*.jar")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err := io.Copy(tmp, in); err != nil {
+		return nil, err
+	}
+
+	return NewComponent(tmp.Name())
+}
+
+func readPomProperties(f *zip.File) (map[string]string, error) {
+	in, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	b, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+
+	properties := map[string]string{}
+	for _, line := range strings.Split(string(b), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "=", 2)
+		if len(parts) != 2 {
+			continue
+		}
+
+		properties[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+
+	return properties, nil
+}
+
+// ReadPomXMLLicenses does a light-weight scan for <license><name>...</name>
+// entries rather than pulling in a full XML-to-struct model, since pom.xml
+// otherwise has no bearing on the SBOM.
+func ReadPomXMLLicenses(f *zip.File) ([]string, error) {
+	in, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer in.Close()
+
+	b, err := ioutil.ReadAll(in)
+	if err != nil {
+		return nil, err
+	}
+
+	var licenses []string
+	content := string(b)
+	for _, segment := range strings.Split(content, "<license>")[1:] {
+		start := strings.Index(segment, "<name>")
+		end := strings.Index(segment, "</name>")
+		if start == -1 || end == -1 || end < start {
+			continue
+		}
+
+		licenses = append(licenses, strings.TrimSpace(segment[start+len("<name>"):end]))
+	}
+
+	return licenses, nil
+}
+
+func hashes(path string) (string, string, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return "", "", fmt.Errorf("unable to open %s\n%w", path, err)
+	}
+	defer in.Close()
+
+	sha1Hash := sha1.New()
+	sha256Hash := sha256.New()
+
+	if _, err := io.Copy(io.MultiWriter(sha1Hash, sha256Hash), in); err != nil {
+		return "", "", fmt.Errorf("unable to hash %s\n%w", path, err)
+	}
+
+	return hex.EncodeToString(sha1Hash.Sum(nil)), hex.EncodeToString(sha256Hash.Sum(nil)), nil
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/buildpacks/libcnb"
+	. "github.com/onsi/gomega"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/spring-boot/boot/sbom"
+)
+
+func testSBOM(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	context("Enabled", func() {
+		it.After(func() {
+			Expect(os.Unsetenv(sbom.EnabledEnvVar)).To(Succeed())
+		})
+
+		it("is disabled by default", func() {
+			Expect(sbom.Enabled(libcnb.BuildpackPlan{})).To(BeFalse())
+		})
+
+		it("is enabled by BP_SBOM_ENABLED", func() {
+			Expect(os.Setenv(sbom.EnabledEnvVar, "true")).To(Succeed())
+			Expect(sbom.Enabled(libcnb.BuildpackPlan{})).To(BeTrue())
+		})
+
+		it("is enabled by an sbom plan entry", func() {
+			plan := libcnb.BuildpackPlan{Entries: []libcnb.BuildpackPlanEntry{{Name: "sbom"}}}
+			Expect(sbom.Enabled(plan)).To(BeTrue())
+		})
+	})
+
+	context("NewCycloneDXDocument", func() {
+		it("contributes a purl and hashes for each component", func() {
+			doc := sbom.NewCycloneDXDocument([]sbom.Component{
+				{
+					GroupID:    "org.springframework.boot",
+					ArtifactID: "spring-boot",
+					Version:    "2.4.0",
+					PURL:       "pkg:maven/org.springframework.boot/spring-boot@2.4.0",
+					SHA1:       "aaaa",
+					SHA256:     "bbbb",
+					Licenses:   []string{"Apache License, Version 2.0"},
+				},
+			})
+
+			Expect(doc.BOMFormat).To(Equal("CycloneDX"))
+			Expect(doc.SpecVersion).To(Equal("1.4"))
+			Expect(doc.Components).To(HaveLen(1))
+			Expect(doc.Components[0].PURL).To(Equal("pkg:maven/org.springframework.boot/spring-boot@2.4.0"))
+			Expect(doc.Components[0].License[0].License.ID).To(Equal("Apache-2.0"))
+		})
+	})
+}
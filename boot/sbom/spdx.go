@@ -0,0 +1,85 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sbom
+
+import "fmt"
+
+// SPDXDocument is a minimal SPDX 2.3 JSON document, containing only the
+// fields the buildpack populates.
+type SPDXDocument struct {
+	SPDXVersion string        `json:"spdxVersion"`
+	DataLicense string        `json:"dataLicense"`
+	SPDXID      string        `json:"SPDXID"`
+	Name        string        `json:"name"`
+	Packages    []SPDXPackage `json:"packages"`
+}
+
+// SPDXPackage is a single SPDX package entry.
+type SPDXPackage struct {
+	SPDXID           string            `json:"SPDXID"`
+	Name             string            `json:"name"`
+	VersionInfo      string            `json:"versionInfo"`
+	LicenseConcluded string            `json:"licenseConcluded"`
+	ExternalRefs     []SPDXExternalRef `json:"externalRefs"`
+	Checksums        []SPDXChecksum    `json:"checksums"`
+}
+
+// SPDXExternalRef references the component's package URL.
+type SPDXExternalRef struct {
+	ReferenceCategory string `json:"referenceCategory"`
+	ReferenceType     string `json:"referenceType"`
+	ReferenceLocator  string `json:"referenceLocator"`
+}
+
+// SPDXChecksum is a single content hash of a package.
+type SPDXChecksum struct {
+	Algorithm     string `json:"algorithm"`
+	ChecksumValue string `json:"checksumValue"`
+}
+
+// NewSPDXDocument converts components into an SPDX 2.3 document.
+func NewSPDXDocument(name string, components []Component) SPDXDocument {
+	doc := SPDXDocument{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        name,
+	}
+
+	for i, c := range components {
+		license := "NOASSERTION"
+		if len(c.Licenses) > 0 {
+			license = SPDXExpression(c.Licenses)
+		}
+
+		doc.Packages = append(doc.Packages, SPDXPackage{
+			SPDXID:           fmt.Sprintf("SPDXRef-Package-%d", i),
+			Name:             c.ArtifactID,
+			VersionInfo:      c.Version,
+			LicenseConcluded: license,
+			ExternalRefs: []SPDXExternalRef{
+				{ReferenceCategory: "PACKAGE-MANAGER", ReferenceType: "purl", ReferenceLocator: c.PURL},
+			},
+			Checksums: []SPDXChecksum{
+				{Algorithm: "SHA1", ChecksumValue: c.SHA1},
+				{Algorithm: "SHA256", ChecksumValue: c.SHA256},
+			},
+		})
+	}
+
+	return doc
+}
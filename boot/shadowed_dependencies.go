@@ -0,0 +1,116 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buildpacks/libcnb"
+
+	"github.com/paketo-buildpacks/spring-boot/boot/sbom"
+)
+
+// ShadowedDependenciesLabel names the dropped-dependency warning label.
+const ShadowedDependenciesLabel = "io.paketo.spring-boot.shadowed-dependencies"
+
+// JDKDistribution returns the distribution name (e.g. "bellsoft-liberica")
+// libjvm contributed to the build plan for the JDK/JRE it installed, or ""
+// if no such entry is present (e.g. the stack image already has a JDK baked
+// in).
+func JDKDistribution(plan libcnb.BuildpackPlan) string {
+	for _, e := range plan.Entries {
+		if e.Name != "jdk" && e.Name != "jre" {
+			continue
+		}
+
+		if d, ok := e.Metadata["distribution"].(string); ok && d != "" {
+			return d
+		}
+	}
+
+	return ""
+}
+
+// NewShadowedDependencies parses the metadata.shadowed-dependencies array
+// buildpack.toml declares into a lookup, keyed by the version-less purl
+// (groupId/artifactId), of the component that already provides it (e.g.
+// "jdk"). A "*" jdk-distribution entry applies regardless of which
+// distribution libjvm installed.
+func NewShadowedDependencies(metadata map[string]interface{}, jdkDistribution string) map[string]string {
+	raw, ok := metadata["shadowed-dependencies"].([]map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	shadowed := map[string]string{}
+	for _, r := range raw {
+		dist, _ := r["jdk-distribution"].(string)
+		if dist != "*" && dist != jdkDistribution {
+			continue
+		}
+
+		purl, _ := r["purl"].(string)
+		providedBy, _ := r["provided-by"].(string)
+		if purl == "" {
+			continue
+		}
+
+		shadowed[purl] = providedBy
+	}
+
+	return shadowed
+}
+
+// TrimShadowedDependencies removes every dependency whose purl (resolved via
+// components, the richer coordinates recovered for the SBOM) matches a known
+// JRE/JDK-provided artifact. It returns the trimmed dependency list and the
+// "<shadowed-jar> -> <provider>" relationships recorded for each dependency
+// dropped.
+func TrimShadowedDependencies(dependencies []Dependency, components []sbom.Component, shadowed map[string]string) ([]Dependency, []string) {
+	if len(shadowed) == 0 {
+		return dependencies, nil
+	}
+
+	purlByArtifactID := map[string]string{}
+	for _, c := range components {
+		purlByArtifactID[c.ArtifactID] = versionlessPURL(c.PURL)
+	}
+
+	var trimmed []Dependency
+	var relationships []string
+
+	for _, d := range dependencies {
+		if purl, ok := purlByArtifactID[d.Name]; ok {
+			if provider, ok := shadowed[purl]; ok {
+				relationships = append(relationships, fmt.Sprintf("%s -> %s", d.Name, provider))
+				continue
+			}
+		}
+
+		trimmed = append(trimmed, d)
+	}
+
+	return trimmed, relationships
+}
+
+func versionlessPURL(purl string) string {
+	if i := strings.LastIndex(purl, "@"); i != -1 {
+		return purl[:i]
+	}
+	return purl
+}
@@ -0,0 +1,67 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/paketo-buildpacks/libjvm"
+	"github.com/sclevine/spec"
+
+	"github.com/paketo-buildpacks/spring-boot/boot"
+	"github.com/paketo-buildpacks/spring-boot/boot/sbom"
+)
+
+func testShadowedDependencies(t *testing.T, context spec.G, it spec.S) {
+	var Expect = NewWithT(t).Expect
+
+	context("NewShadowedDependencies", func() {
+		it("scopes entries to the installed JDK distribution", func() {
+			metadata := map[string]interface{}{
+				"shadowed-dependencies": []map[string]interface{}{
+					{"jdk-distribution": "bellsoft-liberica", "purl": "pkg:maven/g/a", "provided-by": "jdk"},
+					{"jdk-distribution": "*", "purl": "pkg:maven/g/b", "provided-by": "jdk"},
+				},
+			}
+
+			shadowed := boot.NewShadowedDependencies(metadata, "temurin")
+			Expect(shadowed).To(HaveLen(1))
+			Expect(shadowed["pkg:maven/g/b"]).To(Equal("jdk"))
+		})
+	})
+
+	context("TrimShadowedDependencies", func() {
+		it("drops dependencies whose purl is shadowed and records the relationship", func() {
+			dependencies := []boot.Dependency{
+				{MavenJAR: libjvm.MavenJAR{Name: "jakarta.activation-api", Version: "1.2.1"}},
+				{MavenJAR: libjvm.MavenJAR{Name: "spring-core", Version: "5.3.0"}},
+			}
+			components := []sbom.Component{
+				{ArtifactID: "jakarta.activation-api", PURL: "pkg:maven/jakarta.activation/jakarta.activation-api@1.2.1"},
+				{ArtifactID: "spring-core", PURL: "pkg:maven/org.springframework/spring-core@5.3.0"},
+			}
+			shadowed := map[string]string{"pkg:maven/jakarta.activation/jakarta.activation-api": "jdk"}
+
+			trimmed, relationships := boot.TrimShadowedDependencies(dependencies, components, shadowed)
+
+			Expect(trimmed).To(HaveLen(1))
+			Expect(trimmed[0].Name).To(Equal("spring-core"))
+			Expect(relationships).To(Equal([]string{"jakarta.activation-api -> jdk"}))
+		})
+	})
+}
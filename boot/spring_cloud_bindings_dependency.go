@@ -0,0 +1,67 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/buildpacks/libcnb"
+	"github.com/paketo-buildpacks/libpak"
+)
+
+// SpringCloudBindingsDependencyLayerContributor downloads the
+// spring-cloud-bindings jar declared in buildpack.toml and places it on the
+// launch classpath, so that Kubernetes service binding Secrets are
+// translated into Spring Boot configuration properties at runtime.
+type SpringCloudBindingsDependencyLayerContributor struct {
+	LayerContributor libpak.DependencyLayerContributor
+}
+
+// NewSpringCloudBindingsDependencyLayerContributor creates a new
+// SpringCloudBindingsDependencyLayerContributor for dependency.
+func NewSpringCloudBindingsDependencyLayerContributor(dependency libpak.BuildpackDependency, cache libpak.DependencyCache) SpringCloudBindingsDependencyLayerContributor {
+	return SpringCloudBindingsDependencyLayerContributor{
+		LayerContributor: libpak.NewDependencyLayerContributor(dependency, cache, libcnb.LayerTypes{Launch: true}),
+	}
+}
+
+func (s SpringCloudBindingsDependencyLayerContributor) Contribute(layer libcnb.Layer) (libcnb.Layer, error) {
+	return s.LayerContributor.Contribute(layer, func(artifact *os.File) (libcnb.Layer, error) {
+		path := filepath.Join(layer.Path, filepath.Base(artifact.Name()))
+
+		out, err := os.Create(path)
+		if err != nil {
+			return libcnb.Layer{}, fmt.Errorf("unable to create %s\n%w", path, err)
+		}
+		defer out.Close()
+
+		if _, err := io.Copy(out, artifact); err != nil {
+			return libcnb.Layer{}, fmt.Errorf("unable to copy %s to %s\n%w", artifact.Name(), path, err)
+		}
+
+		layer.LaunchEnvironment.Append("CLASSPATH", string(os.PathListSeparator), path)
+
+		return layer, nil
+	})
+}
+
+func (s SpringCloudBindingsDependencyLayerContributor) Name() string {
+	return s.LayerContributor.LayerName()
+}
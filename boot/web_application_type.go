@@ -0,0 +1,85 @@
+/*
+ * Copyright 2018-2020 the original author or authors.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      https://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package boot
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/buildpacks/libcnb"
+)
+
+const (
+	// WebApplicationTypeNone indicates the application is not a web application.
+	WebApplicationTypeNone = "none"
+
+	// WebApplicationTypeServlet indicates the application is a traditional
+	// Servlet-based web application.
+	WebApplicationTypeServlet = "servlet"
+
+	// WebApplicationTypeReactive indicates the application is a reactive web
+	// application.
+	WebApplicationTypeReactive = "reactive"
+)
+
+// WebApplicationType is a libcnb.LayerContributor that detects the kind of
+// web application Spring Boot will start (none, servlet, or reactive) and
+// records it in a layer so that downstream buildpacks (e.g. a health-check
+// process type contribution) can make decisions without re-scanning the
+// classpath themselves. Detection looks only for marker class files under
+// ClassesPath; classes packaged inside jars under BOOT-INF/lib are not
+// inspected.
+type WebApplicationType struct {
+	ApplicationPath string
+	ClassesPath     string
+}
+
+// NewWebApplicationType creates a new WebApplicationType.
+func NewWebApplicationType(applicationPath string, classesPath string) WebApplicationType {
+	return WebApplicationType{ApplicationPath: applicationPath, ClassesPath: classesPath}
+}
+
+func (w WebApplicationType) Contribute(layer libcnb.Layer) (libcnb.Layer, error) {
+	t := w.webApplicationType()
+
+	layer.LayerTypes = libcnb.LayerTypes{Launch: true}
+	layer.LaunchEnvironment.Default("SPRING_WEB_APPLICATION_TYPE", t)
+
+	return layer, nil
+}
+
+func (WebApplicationType) Name() string {
+	return "web-application-type"
+}
+
+func (w WebApplicationType) webApplicationType() string {
+	if w.exists("org/springframework/web/reactive/DispatcherHandler.class") &&
+		!w.exists("org/springframework/web/servlet/DispatcherServlet.class") {
+		return WebApplicationTypeReactive
+	}
+
+	if w.exists("javax/servlet/Servlet.class") || w.exists("jakarta/servlet/Servlet.class") {
+		return WebApplicationTypeServlet
+	}
+
+	return WebApplicationTypeNone
+}
+
+func (w WebApplicationType) exists(p string) bool {
+	_, err := os.Stat(filepath.Join(w.ClassesPath, filepath.FromSlash(p)))
+	return err == nil
+}